@@ -0,0 +1,176 @@
+//--------------------------------------------------------------------------------------------------
+//
+// Copyright (c) 2018 Denis Dyakov
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of this software and
+// associated documentation files (the "Software"), to deal in the Software without restriction,
+// including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all copies or substantial
+// portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+// BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+// DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+//
+//--------------------------------------------------------------------------------------------------
+
+package mpl3115a2
+
+import (
+	"context"
+	"time"
+
+	i2c "github.com/d2r2/go-i2c"
+)
+
+// EventType identify which INT_SOURCE condition fired.
+type EventType int
+
+const (
+	// PressureWindow: pressure/altitude left the configured target window.
+	PressureWindow EventType = iota
+	// PressureThreshold: pressure/altitude crossed the configured target.
+	PressureThreshold
+	// TemperatureWindow: temperature left the configured target window.
+	TemperatureWindow
+	// TemperatureThreshold: temperature crossed the configured target.
+	TemperatureThreshold
+	// Change: pressure/altitude or temperature moved by the configured delta.
+	Change
+)
+
+// Event is a single INT_SOURCE condition observed by Watch.
+type Event struct {
+	Type EventType
+}
+
+// SetPressureTarget program PRES_TGT_MSB_LSB/PRES_WND_MSB_LSB and enable
+// the corresponding CTRL_REG4 interrupts, so INT_SOURCE reports a pressure
+// threshold/window event around targetPa, in barometer mode. targetPa and
+// windowPa are both encoded the same way as ModifySeaLevelPressure (Pa/2),
+// since PRES_WND shares PRES_TGT's 2 Pa/LSB scale in barometer mode.
+func (v *MPL3115A2) SetPressureTarget(i2c *i2c.I2C, targetPa uint32, windowPa uint16) error {
+	target := targetPa / 2
+	b := []byte{PRES_TGT_MSB_LSB, byte(target >> 8), byte(target & 0xFF)}
+	_, err := i2c.WriteBytes(b)
+	if err != nil {
+		return err
+	}
+	window := windowPa / 2
+	w := []byte{PRES_WND_MSB_LSB, byte(window >> 8), byte(window & 0xFF)}
+	_, err = i2c.WriteBytes(w)
+	if err != nil {
+		return err
+	}
+	err = v.enableCtrlReg4(i2c, intEnPW|intEnPTH)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// SetAltitudeTarget program PRES_TGT_MSB_LSB/PRES_WND_MSB_LSB with a signed
+// meters target and an unsigned meters window, in altimeter mode, and
+// enable the corresponding CTRL_REG4 interrupts.
+func (v *MPL3115A2) SetAltitudeTarget(i2c *i2c.I2C, targetM int16, windowM uint16) error {
+	target := uint16(targetM)
+	b := []byte{PRES_TGT_MSB_LSB, byte(target >> 8), byte(target & 0xFF)}
+	_, err := i2c.WriteBytes(b)
+	if err != nil {
+		return err
+	}
+	w := []byte{PRES_WND_MSB_LSB, byte(windowM >> 8), byte(windowM & 0xFF)}
+	_, err = i2c.WriteBytes(w)
+	if err != nil {
+		return err
+	}
+	err = v.enableCtrlReg4(i2c, intEnPW|intEnPTH)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// SetTemperatureTarget program T_TGT/TEMP_WND with a signed *C target and
+// an unsigned *C window, and enable the corresponding CTRL_REG4 interrupts.
+func (v *MPL3115A2) SetTemperatureTarget(i2c *i2c.I2C, targetC int8, windowC uint8) error {
+	b := []byte{T_TGT, byte(targetC)}
+	_, err := i2c.WriteBytes(b)
+	if err != nil {
+		return err
+	}
+	w := []byte{TEMP_WND, windowC}
+	_, err = i2c.WriteBytes(w)
+	if err != nil {
+		return err
+	}
+	err = v.enableCtrlReg4(i2c, intEnTW|intEnTTH)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// Watch start polling INT_SOURCE and stream one Event per threshold/window
+// bit newly asserted since the previous poll (the pressure and temperature
+// change bits together yield a single Change event), until ctx is
+// cancelled, at which point the returned channel is closed. Tracking the
+// previously seen bits this way means a sustained condition (the target
+// stays crossed across several ticks) is only reported once, on the tick
+// it first asserts, instead of flooding the channel on every poll.
+func (v *MPL3115A2) Watch(ctx context.Context, i2c *i2c.I2C) (<-chan Event, error) {
+	ch := make(chan Event)
+	go func() {
+		defer close(ch)
+		ticker := time.NewTicker(100 * time.Millisecond)
+		defer ticker.Stop()
+		var prevSource byte
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				source, err := i2c.ReadRegU8(INT_SOURCE)
+				if err != nil {
+					return
+				}
+				risen := source &^ prevSource
+				prevSource = source
+				for _, et := range decodeEventSource(risen) {
+					select {
+					case ch <- Event{Type: et}:
+					case <-ctx.Done():
+						return
+					}
+				}
+			}
+		}
+	}()
+	return ch, nil
+}
+
+// decodeEventSource translate INT_SOURCE flags into zero or more Events.
+func decodeEventSource(source byte) []EventType {
+	var events []EventType
+	if source&0x20 != 0 {
+		events = append(events, PressureWindow)
+	}
+	if source&0x10 != 0 {
+		events = append(events, TemperatureWindow)
+	}
+	if source&0x08 != 0 {
+		events = append(events, PressureThreshold)
+	}
+	if source&0x04 != 0 {
+		events = append(events, TemperatureThreshold)
+	}
+	if source&0x03 != 0 {
+		events = append(events, Change)
+	}
+	return events
+}
@@ -0,0 +1,134 @@
+//--------------------------------------------------------------------------------------------------
+//
+// Copyright (c) 2018 Denis Dyakov
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of this software and
+// associated documentation files (the "Software"), to deal in the Software without restriction,
+// including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all copies or substantial
+// portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+// BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+// DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+//
+//--------------------------------------------------------------------------------------------------
+
+package mpl3115a2
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	i2c "github.com/d2r2/go-i2c"
+)
+
+// AcquisitionStep is the ST[3:0] step-time field of CTRL_REG2, used in
+// auto-acquisition (continuous ST) mode. The actual step time is 2^step
+// seconds, from 1s up to 2^15s.
+type AcquisitionStep int
+
+const (
+	Step1s AcquisitionStep = iota
+	Step2s
+	Step4s
+	Step8s
+	Step16s
+	Step32s
+	Step64s
+	Step128s
+	Step256s
+	Step512s
+	Step1024s
+	Step2048s
+	Step4096s
+	Step8192s
+	Step16384s
+	Step32768s
+)
+
+// encodeAcquisitionStep validate and translate an AcquisitionStep to the
+// CTRL_REG2 ST[3:0] bits.
+func (v *MPL3115A2) encodeAcquisitionStep(step AcquisitionStep) (byte, error) {
+	if step < Step1s || step > Step32768s {
+		return 0, errors.New("acquisition step should be in range [Step1s..Step32768s]")
+	}
+	return byte(step), nil
+}
+
+// SetAutoAcquisition program the CTRL_REG2 ST[3:0] step time used while the
+// sensor is in auto-acquisition (continuous ST) mode.
+func (v *MPL3115A2) SetAutoAcquisition(i2c *i2c.I2C, step AcquisitionStep) error {
+	b, err := v.encodeAcquisitionStep(step)
+	if err != nil {
+		return err
+	}
+	err = i2c.WriteRegU8(CTRL_REG2, b)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// EnableAutoAcquisition put the sensor into continuous auto-acquisition
+// (SBYB) mode at the given oversample ratio and pressure type, so it keeps
+// sampling on its own at the SetAutoAcquisition step time without being
+// reconfigured for every shot.
+func (v *MPL3115A2) EnableAutoAcquisition(i2c *i2c.I2C, oversampleRatio int, pressureType PressureType) error {
+	return v.armMeasurement(i2c, oversampleRatio, pressureType)
+}
+
+// StreamSamples poll DR_STATUS every period and push a decoded Sample on
+// the returned channel whenever new data is ready, until ctx is cancelled.
+// Call EnableAutoAcquisition first: this only reads out results the sensor
+// is already producing on its own ODR, it never triggers a measurement.
+func (v *MPL3115A2) StreamSamples(ctx context.Context, i2c *i2c.I2C,
+	pressureType PressureType, period time.Duration) (<-chan Sample, error) {
+
+	ch := make(chan Sample)
+	go func() {
+		defer close(ch)
+		ticker := time.NewTicker(period)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				status, err := i2c.ReadRegU8(DR_STATUS)
+				if err != nil {
+					return
+				}
+				if Flag(status)&PRES_TEMP_DATA_READY == 0 {
+					continue
+				}
+				up, ut, err := v.readRawPressureTemperature(i2c)
+				if err != nil {
+					return
+				}
+				var sample Sample
+				if pressureType == AltimeterMode {
+					presInt, presFrac := up.ConvertToSignedQ16Dot4()
+					sample.Pressure = float32(presInt) + float32(presFrac)/(1<<4)
+				} else {
+					presInt, presFrac := up.ConvertToUnsignedQ18Dot2()
+					sample.Pressure = float32(presInt) + float32(presFrac)/(1<<2)
+				}
+				tempInt, tempFrac := ut.ConvertToSignedQ8Dot4()
+				sample.Temperature = float32(tempInt) + float32(tempFrac)/(1<<4)
+				select {
+				case ch <- sample:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return ch, nil
+}
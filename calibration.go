@@ -0,0 +1,141 @@
+//--------------------------------------------------------------------------------------------------
+//
+// Copyright (c) 2018 Denis Dyakov
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of this software and
+// associated documentation files (the "Software"), to deal in the Software without restriction,
+// including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all copies or substantial
+// portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+// BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+// DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+//
+//--------------------------------------------------------------------------------------------------
+
+package mpl3115a2
+
+import (
+	"encoding/binary"
+
+	i2c "github.com/d2r2/go-i2c"
+)
+
+// GetPressureOffset read OFF_PRES back, in the same -512..+508 Pa range
+// CompensatePressure writes, so calibration can be verified after a soft reset.
+func (v *MPL3115A2) GetPressureOffset(i2c *i2c.I2C) (int16, error) {
+	b, err := i2c.ReadRegU8(OFF_PRES)
+	if err != nil {
+		return 0, err
+	}
+	// undo the divide-by-4 CompensatePressure applies before writing
+	return int16(int8(b)) * 4, nil
+}
+
+// GetTemperatureOffset read OFF_TEMP back, in the same -8..+7.9375 *C range
+// CompensateTemperature writes, so calibration can be verified after a soft reset.
+func (v *MPL3115A2) GetTemperatureOffset(i2c *i2c.I2C) (float32, error) {
+	b, err := i2c.ReadRegU8(OFF_TEMP)
+	if err != nil {
+		return 0, err
+	}
+	// undo the multiply-by-16 CompensateTemperature applies before writing
+	return float32(int8(b)) / 16, nil
+}
+
+// GetAltitudeOffset read OFF_H back, in the same -128..+127 m range
+// CompensateAltitude writes, so calibration can be verified after a soft reset.
+func (v *MPL3115A2) GetAltitudeOffset(i2c *i2c.I2C) (int8, error) {
+	b, err := i2c.ReadRegU8(OFF_H)
+	if err != nil {
+		return 0, err
+	}
+	return int8(b), nil
+}
+
+// GetMinMaxPressure read PRES_MIN/PRES_MAX and decode them in barometer
+// mode (Pa), the same conversion MeasurePressure applies.
+func (v *MPL3115A2) GetMinMaxPressure(i2c *i2c.I2C) (min float32, max float32, err error) {
+	minRaw, err := v.readRawPressureExtreme(i2c, PRES_MIN_MSB_CSB_LSB)
+	if err != nil {
+		return 0, 0, err
+	}
+	maxRaw, err := v.readRawPressureExtreme(i2c, PRES_MAX_MSB_CSB_LSB)
+	if err != nil {
+		return 0, 0, err
+	}
+	presInt, presFrac := minRaw.ConvertToUnsignedQ18Dot2()
+	min = float32(presInt) + float32(presFrac)/(1<<2)
+	presInt, presFrac = maxRaw.ConvertToUnsignedQ18Dot2()
+	max = float32(presInt) + float32(presFrac)/(1<<2)
+	return min, max, nil
+}
+
+// GetMinMaxAltitude read PRES_MIN/PRES_MAX and decode them in altimeter
+// mode (m), the same conversion MeasureAltitude applies.
+func (v *MPL3115A2) GetMinMaxAltitude(i2c *i2c.I2C) (min float32, max float32, err error) {
+	minRaw, err := v.readRawPressureExtreme(i2c, PRES_MIN_MSB_CSB_LSB)
+	if err != nil {
+		return 0, 0, err
+	}
+	maxRaw, err := v.readRawPressureExtreme(i2c, PRES_MAX_MSB_CSB_LSB)
+	if err != nil {
+		return 0, 0, err
+	}
+	presInt, presFrac := minRaw.ConvertToSignedQ16Dot4()
+	min = float32(presInt) + float32(presFrac)/(1<<4)
+	presInt, presFrac = maxRaw.ConvertToSignedQ16Dot4()
+	max = float32(presInt) + float32(presFrac)/(1<<4)
+	return min, max, nil
+}
+
+// GetMinMaxTemperature read TEMP_MIN/TEMP_MAX and decode them in *C.
+func (v *MPL3115A2) GetMinMaxTemperature(i2c *i2c.I2C) (min float32, max float32, err error) {
+	minRaw, err := v.readRawTemperatureExtreme(i2c, TEMP_MIN_MSB_LSB)
+	if err != nil {
+		return 0, 0, err
+	}
+	maxRaw, err := v.readRawTemperatureExtreme(i2c, TEMP_MAX_MSB_LSB)
+	if err != nil {
+		return 0, 0, err
+	}
+	tempInt, tempFrac := minRaw.ConvertToSignedQ8Dot4()
+	min = float32(tempInt) + float32(tempFrac)/(1<<4)
+	tempInt, tempFrac = maxRaw.ConvertToSignedQ8Dot4()
+	max = float32(tempInt) + float32(tempFrac)/(1<<4)
+	return min, max, nil
+}
+
+// readRawPressureExtreme read a 3-byte PRES_MIN/PRES_MAX frame at reg.
+func (v *MPL3115A2) readRawPressureExtreme(i2c *i2c.I2C, reg byte) (*RawPressure, error) {
+	_, err := i2c.WriteBytes([]byte{reg})
+	if err != nil {
+		return nil, err
+	}
+	var data RawPressure
+	err = readDataToStruct(i2c, OUT_PRES_BYTES, binary.LittleEndian, &data)
+	if err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// readRawTemperatureExtreme read a 2-byte TEMP_MIN/TEMP_MAX frame at reg.
+func (v *MPL3115A2) readRawTemperatureExtreme(i2c *i2c.I2C, reg byte) (*RawTemperature, error) {
+	_, err := i2c.WriteBytes([]byte{reg})
+	if err != nil {
+		return nil, err
+	}
+	var data RawTemperature
+	err = readDataToStruct(i2c, OUT_TEMP_BYTES, binary.LittleEndian, &data)
+	if err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
@@ -0,0 +1,41 @@
+//--------------------------------------------------------------------------------------------------
+//
+// Copyright (c) 2018 Denis Dyakov
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of this software and
+// associated documentation files (the "Software"), to deal in the Software without restriction,
+// including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all copies or substantial
+// portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+// BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+// DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+//
+//--------------------------------------------------------------------------------------------------
+
+package mpl3115a2
+
+import "testing"
+
+// TestMinMaxTemperatureRegistersDoNotOverlapPressure guards against the
+// TEMP_MIN_MSB_LSB typo that once aliased PRES_MIN's LSB byte: every
+// register GetMinMaxTemperature reads from must fall strictly after the
+// 3-byte PRES_MIN/PRES_MAX frames GetMinMaxPressure/GetMinMaxAltitude read.
+func TestMinMaxTemperatureRegistersDoNotOverlapPressure(t *testing.T) {
+	presMinEnd := PRES_MIN_MSB_CSB_LSB + PRES_MIN_BYTES - 1
+	if TEMP_MIN_MSB_LSB <= presMinEnd {
+		t.Fatalf("TEMP_MIN_MSB_LSB (0x%02X) overlaps PRES_MIN frame ending at 0x%02X",
+			TEMP_MIN_MSB_LSB, presMinEnd)
+	}
+	presMaxEnd := PRES_MAX_MSB_CSB_LSB + PRES_MAX_BYTES - 1
+	if TEMP_MAX_MSB_LSB <= presMaxEnd {
+		t.Fatalf("TEMP_MAX_MSB_LSB (0x%02X) overlaps PRES_MAX frame ending at 0x%02X",
+			TEMP_MAX_MSB_LSB, presMaxEnd)
+	}
+}
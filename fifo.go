@@ -0,0 +1,161 @@
+//--------------------------------------------------------------------------------------------------
+//
+// Copyright (c) 2018 Denis Dyakov
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of this software and
+// associated documentation files (the "Software"), to deal in the Software without restriction,
+// including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all copies or substantial
+// portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+// BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+// DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+//
+//--------------------------------------------------------------------------------------------------
+
+package mpl3115a2
+
+import (
+	"encoding/binary"
+	"errors"
+	"time"
+
+	i2c "github.com/d2r2/go-i2c"
+)
+
+const (
+	// F_STATUS flag: FIFO has overflowed since the last F_SETUP write.
+	F_OVF Flag = 0x80
+	// F_STATUS flag: FIFO sample count reached the configured watermark.
+	F_WMRK_FLAG Flag = 0x40
+	// F_STATUS mask: number of samples currently buffered in the FIFO.
+	F_CNT_MASK = 0x3F
+)
+
+// FIFOMode defines how the 32-sample FIFO buffers pressure/temperature
+// samples between reads.
+type FIFOMode int
+
+const (
+	// FIFODisabled turns the FIFO off; samples are not buffered.
+	FIFODisabled FIFOMode = iota
+	// FIFOCircular overwrites the oldest buffered sample once the FIFO fills up.
+	FIFOCircular
+	// FIFOStopOnOverflow stops accepting new samples once the FIFO fills up,
+	// until it's read out and re-armed via EnableFIFO.
+	FIFOStopOnOverflow
+)
+
+// encodeFIFOMode translate FIFOMode to the F_SETUP F_MODE[7:6] bits.
+func (v *MPL3115A2) encodeFIFOMode(mode FIFOMode) (byte, error) {
+	switch mode {
+	case FIFODisabled:
+		return 0x00, nil
+	case FIFOCircular:
+		return 0x40, nil
+	case FIFOStopOnOverflow:
+		return 0x80, nil
+	default:
+		return 0, errors.New("unknown FIFO mode")
+	}
+}
+
+// EnableFIFO configure the FIFO operation mode and the watermark level
+// (sample count in range [0..31]) that raises F_WMRK_FLAG in F_STATUS.
+func (v *MPL3115A2) EnableFIFO(i2c *i2c.I2C, mode FIFOMode, watermark int) error {
+	if watermark < 0 || watermark > 31 {
+		return errors.New("FIFO watermark should be in range [0..31]")
+	}
+	b, err := v.encodeFIFOMode(mode)
+	if err != nil {
+		return err
+	}
+	b |= byte(watermark)
+	err = i2c.WriteRegU8(F_SETUP, b)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// Sample keeps one decoded FIFO entry. Overflow/Watermark reflect the
+// F_STATUS flags read at burst-read time and apply to the whole batch.
+type Sample struct {
+	Pressure    float32
+	Temperature float32
+	Overflow    bool
+	Watermark   bool
+}
+
+// fifoSampleBytes is the size of a single P/T frame stored behind F_DATA.
+const fifoSampleBytes = OUT_PRES_BYTES + OUT_TEMP_BYTES
+
+// ReadFIFO burst-read every sample currently buffered in the FIFO and
+// decode it. Each read of F_DATA pops the oldest buffered P/T frame, so
+// this reads exactly as many frames as F_STATUS reports are queued.
+// Decoding assumes the sensor is in barometer mode, matching MeasurePressure.
+func (v *MPL3115A2) ReadFIFO(i2c *i2c.I2C) ([]Sample, error) {
+	status, err := i2c.ReadRegU8(F_STATUS)
+	if err != nil {
+		return nil, err
+	}
+	overflow := Flag(status)&F_OVF != 0
+	watermark := Flag(status)&F_WMRK_FLAG != 0
+	count := int(status & F_CNT_MASK)
+
+	samples := make([]Sample, 0, count)
+	for i := 0; i < count; i++ {
+		_, err := i2c.WriteBytes([]byte{F_DATA})
+		if err != nil {
+			return nil, err
+		}
+		var data struct {
+			RawPressure
+			RawTemperature
+		}
+		err = readDataToStruct(i2c, fifoSampleBytes, binary.LittleEndian, &data)
+		if err != nil {
+			return nil, err
+		}
+		presInt, presFrac := data.RawPressure.ConvertToUnsignedQ18Dot2()
+		tempInt, tempFrac := data.RawTemperature.ConvertToSignedQ8Dot4()
+		samples = append(samples, Sample{
+			Pressure:    float32(presInt) + float32(presFrac)/(1<<2),
+			Temperature: float32(tempInt) + float32(tempFrac)/(1<<4),
+			Overflow:    overflow,
+			Watermark:   watermark,
+		})
+	}
+	return samples, nil
+}
+
+// acquisitionStepDuration read CTRL_REG2 ST[3:0] and return the configured
+// auto-acquisition step time, 2^ST seconds.
+func (v *MPL3115A2) acquisitionStepDuration(i2c *i2c.I2C) (time.Duration, error) {
+	reg2, err := i2c.ReadRegU8(CTRL_REG2)
+	if err != nil {
+		return 0, err
+	}
+	st := reg2 & 0x0F
+	return time.Second * time.Duration(uint32(1)<<st), nil
+}
+
+// ReadTimeSinceOverflow read TIME_DLY (ticks elapsed since the last FIFO
+// overflow event) and scale it by the CTRL_REG2 acquisition step time.
+func (v *MPL3115A2) ReadTimeSinceOverflow(i2c *i2c.I2C) (time.Duration, error) {
+	ticks, err := i2c.ReadRegU8(TIME_DLY)
+	if err != nil {
+		return 0, err
+	}
+	step, err := v.acquisitionStepDuration(i2c)
+	if err != nil {
+		return 0, err
+	}
+	return step * time.Duration(ticks), nil
+}
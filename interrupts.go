@@ -0,0 +1,207 @@
+//--------------------------------------------------------------------------------------------------
+//
+// Copyright (c) 2018 Denis Dyakov
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of this software and
+// associated documentation files (the "Software"), to deal in the Software without restriction,
+// including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all copies or substantial
+// portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+// BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+// DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+//
+//--------------------------------------------------------------------------------------------------
+
+package mpl3115a2
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"syscall"
+	"unsafe"
+
+	i2c "github.com/d2r2/go-i2c"
+)
+
+// InterruptPin identify which INT pin (INT1 or INT2) a source is routed to.
+type InterruptPin int
+
+const (
+	// INT1 is the sensor's primary interrupt pin.
+	INT1 InterruptPin = iota
+	// INT2 is the sensor's secondary interrupt pin.
+	INT2
+)
+
+// InterruptConfig groups the CTRL_REG3/CTRL_REG5 settings applied by
+// ConfigureInterrupts. CTRL_REG4 only gets the data-ready interrupt enabled,
+// since that's all WaitForDataReady needs.
+type InterruptConfig struct {
+	// OpenDrain configure the DataReadyPin INT pin as open-drain (true) or
+	// push-pull (false). The other INT pin is left push-pull.
+	OpenDrain bool
+	// ActiveHigh configure the DataReadyPin INT pin polarity. The other INT
+	// pin is left active low.
+	ActiveHigh bool
+	// DataReadyPin route the data-ready interrupt (INT_SOURCE bit 0x80) to INT1 or INT2.
+	DataReadyPin InterruptPin
+}
+
+// CTRL_REG4 interrupt-enable bits. SetPressureTarget/SetAltitudeTarget/
+// SetTemperatureTarget in alerts.go enable the window/threshold bits
+// through the same enableCtrlReg4 read-modify-write, so turning on one
+// interrupt source here never clobbers another subsystem's bits.
+const (
+	intEnDRDY Flag = 0x80
+	intEnPW   Flag = 0x20
+	intEnTW   Flag = 0x10
+	intEnPTH  Flag = 0x08
+	intEnTTH  Flag = 0x04
+)
+
+// enableCtrlReg4 read-modify-write CTRL_REG4, OR-ing bits into whatever is
+// already enabled.
+func (v *MPL3115A2) enableCtrlReg4(i2c *i2c.I2C, bits Flag) error {
+	reg4, err := i2c.ReadRegU8(CTRL_REG4)
+	if err != nil {
+		return err
+	}
+	err = i2c.WriteRegU8(CTRL_REG4, reg4|byte(bits))
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// ConfigureInterrupts program CTRL_REG3 (pin electrical characteristics),
+// CTRL_REG4 (interrupt enables) and CTRL_REG5 (interrupt routing).
+func (v *MPL3115A2) ConfigureInterrupts(i2c *i2c.I2C, cfg InterruptConfig) error {
+	// PP_OD2/IPOL2 (INT2) live at bits 0/1, PP_OD1/IPOL1 (INT1) at bits 4/5.
+	var openDrainBit, activeHighBit byte
+	if cfg.DataReadyPin == INT1 {
+		openDrainBit, activeHighBit = 0x10, 0x20
+	} else {
+		openDrainBit, activeHighBit = 0x01, 0x02
+	}
+	var reg3 byte
+	if cfg.OpenDrain {
+		reg3 |= openDrainBit
+	}
+	if cfg.ActiveHigh {
+		reg3 |= activeHighBit
+	}
+	err := i2c.WriteRegU8(CTRL_REG3, reg3)
+	if err != nil {
+		return err
+	}
+	// enable the data-ready interrupt
+	err = v.enableCtrlReg4(i2c, intEnDRDY)
+	if err != nil {
+		return err
+	}
+	var reg5 byte
+	if cfg.DataReadyPin == INT1 {
+		reg5 = 0x80
+	}
+	err = i2c.WriteRegU8(CTRL_REG5, reg5)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// WaitForDataReady arm the sensor (see armMeasurement), then block on a
+// Linux GPIO edge on gpioPin instead of busy-polling STATUS as measureRaw
+// does, and confirm the data-ready source in INT_SOURCE before reading the
+// raw sample. gpioPin must already be exported under /sys/class/gpio and
+// configured for the edge matching ConfigureInterrupts' ActiveHigh setting.
+func (v *MPL3115A2) WaitForDataReady(ctx context.Context, i2c *i2c.I2C,
+	oversampleRatio int, pressureType PressureType, gpioPin int) (*RawPressure, *RawTemperature, error) {
+
+	err := v.armMeasurement(i2c, oversampleRatio, pressureType)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = waitGPIOEdge(ctx, gpioPin)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	source, err := i2c.ReadRegU8(INT_SOURCE)
+	if err != nil {
+		return nil, nil, err
+	}
+	if source&0x80 == 0 {
+		return nil, nil, errors.New("GPIO edge fired without a data-ready interrupt source")
+	}
+
+	up, ut, err := v.readRawPressureTemperature(i2c)
+	if err != nil {
+		return nil, nil, err
+	}
+	return up, ut, nil
+}
+
+// waitGPIOEdge block on a sysfs GPIO value file until an edge event is
+// signalled or ctx is cancelled. gpioPin is expected to already be exported
+// and have its edge trigger configured (see ConfigureInterrupts).
+func waitGPIOEdge(ctx context.Context, gpioPin int) error {
+	path := fmt.Sprintf("/sys/class/gpio/gpio%d/value", gpioPin)
+	f, err := os.OpenFile(path, os.O_RDONLY, 0)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	// consume the current value so the first poll below waits for the next edge
+	buf := make([]byte, 1)
+	_, err = f.ReadAt(buf, 0)
+	if err != nil {
+		return err
+	}
+
+	fd := int(f.Fd())
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+		var exceptFds syscall.FdSet
+		fdSet(&exceptFds, fd)
+		timeout := syscall.Timeval{Sec: 0, Usec: 200000}
+		n, err := syscall.Select(fd+1, nil, nil, &exceptFds, &timeout)
+		if err != nil {
+			return err
+		}
+		if n > 0 {
+			_, err = f.ReadAt(buf, 0)
+			if err != nil {
+				return err
+			}
+			return nil
+		}
+	}
+}
+
+// fdSet mark fd as set within a syscall.FdSet. syscall.FdSet.Bits is
+// [16]int64 on linux/amd64 but [32]int32 on linux/arm (32-bit Raspberry Pi,
+// this driver's primary target), so the word width can't be hardcoded to
+// 64 without silently dropping fd >= 32 on ARM; derive it from the actual
+// element size instead.
+func fdSet(set *syscall.FdSet, fd int) {
+	wordBits := int(unsafe.Sizeof(set.Bits[0])) * 8
+	word := reflect.ValueOf(&set.Bits[fd/wordBits]).Elem()
+	word.SetInt(word.Int() | (1 << uint(fd%wordBits)))
+}
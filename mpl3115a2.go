@@ -100,7 +100,7 @@ const (
 	PRES_MIN_BYTES       = 3
 
 	// Minimum temperature
-	TEMP_MIN_MSB_LSB = 0x1E
+	TEMP_MIN_MSB_LSB = 0x1F
 	TEMP_MIN_BYTES   = 2
 
 	// Maximum pressure/altitude
@@ -153,10 +153,19 @@ const (
 type PressureType int
 
 const (
-	// Measure pressure in Pa
-	Barometer PressureType = iota + 1
-	// Measure altitude in m
-	Altimeter
+	// BarometerMode measure pressure in Pa.
+	//
+	// Deprecated: renamed from Barometer, which is now the typed handle
+	// returned by (*MPL3115A2).ToBarometer and can no longer also name this
+	// constant. This is a breaking change for any caller referencing
+	// mpl3115a2.Barometer as a PressureType value; such callers must switch
+	// to BarometerMode (or, better, to ToBarometer()).
+	BarometerMode PressureType = iota + 1
+	// AltimeterMode measure altitude in m.
+	//
+	// Deprecated: renamed from Altimeter for the same reason as
+	// BarometerMode; prefer ToAltitude().
+	AltimeterMode
 )
 
 // RawPressure keeps raw pressure data received from sensor.
@@ -201,6 +210,15 @@ func (v *RawTemperature) ConvertToSignedQ8Dot4() (int8, uint8) {
 
 // MPL3115A2 keeps sensor itself.
 type MPL3115A2 struct {
+	// armed, armedMode and armedOversampleRatio cache the mode/OSR bits
+	// last written to CTRL_REG1 by armMeasurement, so that Barometer and
+	// Altimeter handles sharing the same device only rewrite CTRL_REG1
+	// when the requested mode or oversample ratio actually changes. This
+	// lives on the device, not on the handle, since CTRL_REG1 itself is a
+	// single physical register shared by every handle/goroutine touching it.
+	armed                bool
+	armedMode            PressureType
+	armedOversampleRatio int
 }
 
 // NewMPL3115A2 return new sensor instance.
@@ -285,88 +303,113 @@ func (v *MPL3115A2) writeEventMode(i2c *i2c.I2C,
 
 // MeasureAltitude measure altitude in meters with specific
 // precision defined by oversample ratio.
+//
+// Deprecated: use (*MPL3115A2).ToAltitude instead, which makes the mode
+// switch explicit instead of overloading one function for both units.
 func (v *MPL3115A2) MeasureAltitude(i2c *i2c.I2C, oversampleRatio int) (float32, float32, error) {
-	up, ut, err := v.measureRaw(i2c, oversampleRatio, Altimeter)
+	alt, t, err := v.ToAltitude().ReadAltitude(i2c, oversampleRatio)
 	if err != nil {
 		return 0, 0, err
 	}
-	presInt, presFrac := up.ConvertToSignedQ16Dot4()
-	tempInt, tempFrac := ut.ConvertToSignedQ8Dot4()
-	alt := float32(presInt) + float32(presFrac)/(1<<4)
-	t := float32(tempInt) + float32(tempFrac)/(1<<4)
-	return alt, t, nil
+	return float32(alt), t, nil
 }
 
 // MeasurePressure measure pressure in Pa with specific
 // precision defined by oversample ratio.
+//
+// Deprecated: use (*MPL3115A2).ToBarometer instead, which makes the mode
+// switch explicit instead of overloading one function for both units.
 func (v *MPL3115A2) MeasurePressure(i2c *i2c.I2C, oversampleRation int) (float32, float32, error) {
-	up, ut, err := v.measureRaw(i2c, oversampleRation, Barometer)
+	pres, t, err := v.ToBarometer().ReadPressure(i2c, oversampleRation)
 	if err != nil {
 		return 0, 0, err
 	}
-	presInt, presFrac := up.ConvertToUnsignedQ18Dot2()
-	tempInt, tempFrac := ut.ConvertToSignedQ8Dot4()
-	pres := float32(presInt) + float32(presFrac)/(1<<2)
-	t := float32(tempInt) + float32(tempFrac)/(1<<4)
-	return pres, t, nil
+	return float32(pres), t, nil
 }
 
-// Initialize sensor and made raw measurement
-// to read uncompensated pressure and temperature.
-func (v *MPL3115A2) measureRaw(i2c *i2c.I2C, overampleRatio int,
-	pressureType PressureType) (*RawPressure, *RawTemperature, error) {
+// armMeasurement enable the requested mode and oversample ratio in
+// CTRL_REG1, enable P/T events and set SBYB, after which the sensor keeps
+// sampling on its own. Does nothing when already armed with the same mode
+// and oversample ratio.
+func (v *MPL3115A2) armMeasurement(i2c *i2c.I2C, overampleRatio int,
+	pressureType PressureType) error {
 
-	lg.Debug("Measurement pressure and temperature...")
+	if v.armed && v.armedMode == pressureType && v.armedOversampleRatio == overampleRatio {
+		return nil
+	}
 
 	// enable Altimeter mode
 	var barometerType bool
-	if pressureType == Altimeter {
+	if pressureType == AltimeterMode {
 		barometerType = true
 	}
 	flags, err := v.encodeCtrlAltimeterMode(barometerType)
 	if err != nil {
-		return nil, nil, err
+		return err
 	}
 	// define Oversample Ratio to 2^oversampleRatio
 	b, err := v.encodeCtrlOverSampleRatio(overampleRatio)
 	if err != nil {
-		return nil, nil, err
+		return err
 	}
 	flags |= b
 	// activate Altimeter mode and set Oversample Ratio
 	err = v.writeCtrlReg1(i2c, flags)
 	if err != nil {
-		return nil, nil, err
+		return err
 	}
 	// enable events for temperature and pressure
 	err = v.writeEventMode(i2c, true, true)
 	if err != nil {
-		return nil, nil, err
+		return err
 	}
 	// get activate sensor bit
 	b, err = v.encodeCtrlActiveStatus(true)
 	if err != nil {
-		return nil, nil, err
+		return err
 	}
 	flags |= b
 	// activate sensor
 	err = v.writeCtrlReg1(i2c, flags)
 	if err != nil {
-		return nil, nil, err
+		return err
 	}
-	// read status until measurement is done
+	v.armed = true
+	v.armedMode = pressureType
+	v.armedOversampleRatio = overampleRatio
+	return nil
+}
+
+// waitDataReady poll STATUS until a pressure/temperature sample is ready.
+func (v *MPL3115A2) waitDataReady(i2c *i2c.I2C) error {
 	for {
 		var n time.Duration = 1
-		// n = 1 << overampleRatio
 		time.Sleep(time.Millisecond * 2 * n)
 		status, err := v.readStatusReg(i2c)
 		if err != nil {
-			return nil, nil, err
+			return err
 		}
 		if status&PRES_TEMP_DATA_READY != 0 {
-			break
+			return nil
 		}
 	}
+}
+
+// Initialize sensor and made raw measurement
+// to read uncompensated pressure and temperature.
+func (v *MPL3115A2) measureRaw(i2c *i2c.I2C, overampleRatio int,
+	pressureType PressureType) (*RawPressure, *RawTemperature, error) {
+
+	lg.Debug("Measurement pressure and temperature...")
+
+	err := v.armMeasurement(i2c, overampleRatio, pressureType)
+	if err != nil {
+		return nil, nil, err
+	}
+	err = v.waitDataReady(i2c)
+	if err != nil {
+		return nil, nil, err
+	}
 	up, ut, err := v.readRawPressureTemperature(i2c)
 	if err != nil {
 		return nil, nil, err
@@ -422,6 +465,7 @@ func (v *MPL3115A2) Reset(i2c *i2c.I2C) error {
 	// activate reset bit
 	err = v.writeCtrlReg1(i2c, flags)
 	// ignore error, since sensor terminates i2c-connection
+	v.armed = false
 	return nil
 }
 
@@ -462,7 +506,7 @@ func (v *MPL3115A2) CompensateTemperature(i2c *i2c.I2C, shiftTemp float32) error
 	}
 	// multiply by 16
 	shiftTemp = shiftTemp * 16
-	b := []byte{OFF_PRES, byte(shiftTemp)}
+	b := []byte{OFF_TEMP, byte(shiftTemp)}
 	_, err := i2c.WriteBytes(b)
 	if err != nil {
 		return err
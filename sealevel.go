@@ -0,0 +1,75 @@
+//--------------------------------------------------------------------------------------------------
+//
+// Copyright (c) 2018 Denis Dyakov
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of this software and
+// associated documentation files (the "Software"), to deal in the Software without restriction,
+// including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all copies or substantial
+// portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+// BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+// DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+//
+//--------------------------------------------------------------------------------------------------
+
+package mpl3115a2
+
+import (
+	"math"
+
+	i2c "github.com/d2r2/go-i2c"
+)
+
+// Standard atmosphere constants used by PressureToSeaLevel/AltitudeFromPressure,
+// the same reduction the collectd barometer plugin uses to publish pressure_reduced.
+const (
+	seaLevelLapseRate   = 0.0065    // L, K/m
+	seaLevelGravity     = 9.80665   // g, m/s^2
+	seaLevelMolarMass   = 0.0289644 // M, kg/mol
+	seaLevelGasConstant = 8.31447   // R, J/(mol*K)
+)
+
+// PressureToSeaLevel reduce a station-level pressure reading pStation (Pa),
+// taken at stationAltitudeM meters and tempC degrees Celsius, to the
+// equivalent sea-level pressure (Pa) via the standard barometric formula
+// p0 = p * (1 - L*h/(T + L*h + 273.15))^(-g*M/(R*L)).
+func PressureToSeaLevel(pStation float32, stationAltitudeM float32, tempC float32) float32 {
+	h := float64(stationAltitudeM)
+	t := float64(tempC)
+	term := 1 - (seaLevelLapseRate*h)/(t+seaLevelLapseRate*h+273.15)
+	exponent := -(seaLevelGravity * seaLevelMolarMass) / (seaLevelGasConstant * seaLevelLapseRate)
+	p0 := float64(pStation) * math.Pow(term, exponent)
+	return float32(p0)
+}
+
+// AltitudeFromPressure derive altitude (m) from a station pressure pPa (Pa)
+// and a reference sea-level pressure seaLevelPa (Pa), via the inverse
+// hypsometric formula h = 44330 * (1 - (p/p0)^(1/5.255)).
+func AltitudeFromPressure(pPa float32, seaLevelPa float32) float32 {
+	h := 44330 * (1 - math.Pow(float64(pPa)/float64(seaLevelPa), 1/5.255))
+	return float32(h)
+}
+
+// CalibrateSeaLevelFromKnownAltitude measure pressure once in barometer
+// mode, derive the sea-level pressure implied by a known station altitude,
+// and persist it via ModifySeaLevelPressure, so MeasureAltitude reflects
+// the current weather instead of the default standard atmosphere.
+func (v *MPL3115A2) CalibrateSeaLevelFromKnownAltitude(i2c *i2c.I2C, oversampleRatio int, knownAltM float32) error {
+	p, t, err := v.MeasurePressure(i2c, oversampleRatio)
+	if err != nil {
+		return err
+	}
+	seaLevelPa := PressureToSeaLevel(p, knownAltM, t)
+	err = v.ModifySeaLevelPressure(i2c, uint32(seaLevelPa))
+	if err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+//--------------------------------------------------------------------------------------------------
+//
+// Copyright (c) 2018 Denis Dyakov
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of this software and
+// associated documentation files (the "Software"), to deal in the Software without restriction,
+// including without limitation the rights to use, copy, modify, merge, publish, distribute, sublicense,
+// and/or sell copies of the Software, and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all copies or substantial
+// portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED, INCLUDING
+// BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND
+// NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM,
+// DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+//
+//--------------------------------------------------------------------------------------------------
+
+package mpl3115a2
+
+import (
+	i2c "github.com/d2r2/go-i2c"
+)
+
+// Pascals is a pressure reading, as returned by Barometer.ReadPressure.
+type Pascals float32
+
+// Meters is an altitude reading, as returned by Altimeter.ReadAltitude.
+type Meters float32
+
+// Barometer is a MPL3115A2 handle locked into barometer (pressure, Pa)
+// mode. Obtain one with (*MPL3115A2).ToBarometer. ReadPressure only
+// rewrites CTRL_REG1 when the requested mode or oversample ratio actually
+// changes; that cache lives on the shared *MPL3115A2 (not the handle), so
+// an Altimeter and a Barometer obtained from the same device can't each
+// believe they're still armed in their own mode and decode the other
+// mode's raw bytes.
+type Barometer struct {
+	dev *MPL3115A2
+}
+
+// ToBarometer return a Barometer handle for sensor v.
+func (v *MPL3115A2) ToBarometer() *Barometer {
+	return &Barometer{dev: v}
+}
+
+// ReadPressure measure pressure (Pa) and temperature (*C) at the given
+// oversample ratio, only reconfiguring CTRL_REG1 when the sensor isn't
+// already armed in barometer mode at that ratio.
+func (b *Barometer) ReadPressure(i2c *i2c.I2C, oversampleRatio int) (Pascals, float32, error) {
+	err := b.dev.armMeasurement(i2c, oversampleRatio, BarometerMode)
+	if err != nil {
+		return 0, 0, err
+	}
+	err = b.dev.waitDataReady(i2c)
+	if err != nil {
+		return 0, 0, err
+	}
+	up, ut, err := b.dev.readRawPressureTemperature(i2c)
+	if err != nil {
+		return 0, 0, err
+	}
+	presInt, presFrac := up.ConvertToUnsignedQ18Dot2()
+	tempInt, tempFrac := ut.ConvertToSignedQ8Dot4()
+	pres := float32(presInt) + float32(presFrac)/(1<<2)
+	t := float32(tempInt) + float32(tempFrac)/(1<<4)
+	return Pascals(pres), t, nil
+}
+
+// Altimeter is a MPL3115A2 handle locked into altimeter (altitude, m)
+// mode. Obtain one with (*MPL3115A2).ToAltitude. ReadAltitude only
+// rewrites CTRL_REG1 when the requested mode or oversample ratio actually
+// changes; that cache lives on the shared *MPL3115A2 (not the handle), so
+// an Altimeter and a Barometer obtained from the same device can't each
+// believe they're still armed in their own mode and decode the other
+// mode's raw bytes.
+type Altimeter struct {
+	dev *MPL3115A2
+}
+
+// ToAltitude return an Altimeter handle for sensor v.
+func (v *MPL3115A2) ToAltitude() *Altimeter {
+	return &Altimeter{dev: v}
+}
+
+// ReadAltitude measure altitude (m) and temperature (*C) at the given
+// oversample ratio, only reconfiguring CTRL_REG1 when the sensor isn't
+// already armed in altimeter mode at that ratio.
+func (a *Altimeter) ReadAltitude(i2c *i2c.I2C, oversampleRatio int) (Meters, float32, error) {
+	err := a.dev.armMeasurement(i2c, oversampleRatio, AltimeterMode)
+	if err != nil {
+		return 0, 0, err
+	}
+	err = a.dev.waitDataReady(i2c)
+	if err != nil {
+		return 0, 0, err
+	}
+	up, ut, err := a.dev.readRawPressureTemperature(i2c)
+	if err != nil {
+		return 0, 0, err
+	}
+	presInt, presFrac := up.ConvertToSignedQ16Dot4()
+	tempInt, tempFrac := ut.ConvertToSignedQ8Dot4()
+	alt := float32(presInt) + float32(presFrac)/(1<<4)
+	t := float32(tempInt) + float32(tempFrac)/(1<<4)
+	return Meters(alt), t, nil
+}
+
+// SetOffset shift altitude from -128 to +127 meters via OFF_H, same as
+// CompensateAltitude. Default value is 0.
+func (a *Altimeter) SetOffset(i2c *i2c.I2C, shiftM int8) error {
+	return a.dev.CompensateAltitude(i2c, shiftM)
+}